@@ -0,0 +1,141 @@
+package logger
+
+import "sync"
+
+// asyncPipeline holds the state of one EnableAsync run: the queue records
+// are pushed onto, the signal used to stop the background goroutine, and a
+// pending count so Flush can tell when every queued record has actually
+// been written rather than merely dequeued.
+type asyncPipeline struct {
+	queue   chan Record
+	done    chan struct{}
+	pending sync.WaitGroup
+	workers sync.WaitGroup
+}
+
+// asyncPipe is non-nil once EnableAsync has been called; formatMessage
+// pushes Records onto it instead of writing synchronously. Access is
+// guarded by asyncMu since it is read and replaced from different
+// goroutines (the logging caller and EnableAsync/Close).
+var (
+	asyncMu   sync.Mutex
+	asyncPipe *asyncPipeline
+)
+
+// EnableAsync switches logging to an asynchronous pipeline: every Record is
+// pushed onto a channel buffered to bufferSize and written by a single
+// background goroutine, so the caller's hot path never blocks on a slow
+// backend. When the buffer is full the oldest queued record is dropped to
+// make room for the new one (drop-oldest, not block) — callers that need
+// every record delivered should size bufferSize generously and call Flush
+// before any point where dropped records would matter. Calling EnableAsync
+// again while already enabled is a no-op; call Close first to reconfigure.
+func EnableAsync(bufferSize int) {
+	asyncMu.Lock()
+	defer asyncMu.Unlock()
+
+	if asyncPipe != nil {
+		return
+	}
+
+	p := &asyncPipeline{
+		queue: make(chan Record, bufferSize),
+		done:  make(chan struct{}),
+	}
+	asyncPipe = p
+
+	p.workers.Add(1)
+	go p.run()
+}
+
+// run drains p.queue until p.done is closed, then drains whatever remains
+// in the buffer before returning. Every record taken off the queue, for
+// writing or while draining, marks itself done in p.pending once
+// writeRecord returns, so Flush can observe the write having completed
+// rather than just the queue having emptied.
+func (p *asyncPipeline) run() {
+	defer p.workers.Done()
+	for {
+		select {
+		case r := <-p.queue:
+			writeRecord(r)
+			p.pending.Done()
+		case <-p.done:
+			for {
+				select {
+				case r := <-p.queue:
+					writeRecord(r)
+					p.pending.Done()
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// asyncEnabled reports whether async logging is active and returns its
+// pipeline.
+func asyncEnabled() (*asyncPipeline, bool) {
+	asyncMu.Lock()
+	defer asyncMu.Unlock()
+	return asyncPipe, asyncPipe != nil
+}
+
+// enqueue pushes r onto queue, tracking it in pending until it has been
+// written, and drops the oldest queued record instead of blocking when the
+// buffer is full.
+func enqueue(queue chan Record, pending *sync.WaitGroup, r Record) {
+	pending.Add(1)
+
+	select {
+	case queue <- r:
+		return
+	default:
+	}
+
+	select {
+	case <-queue:
+		pending.Done()
+	default:
+	}
+
+	select {
+	case queue <- r:
+	default:
+		pending.Done()
+	}
+}
+
+// Flush blocks until every record queued so far by EnableAsync has actually
+// been written, not merely dequeued. It is a no-op when async logging is
+// not enabled.
+func Flush() {
+	p, ok := asyncEnabled()
+	if !ok {
+		return
+	}
+	p.pending.Wait()
+}
+
+// Close drains and stops the pipeline started by EnableAsync, blocking
+// until the background goroutine has exited. It is a no-op when async
+// logging is not enabled.
+func Close() {
+	asyncMu.Lock()
+	p := asyncPipe
+	asyncMu.Unlock()
+
+	if p == nil {
+		return
+	}
+
+	close(p.done)
+	p.workers.Wait()
+
+	asyncMu.Lock()
+	if asyncPipe == p {
+		asyncPipe = nil
+	}
+	asyncMu.Unlock()
+}
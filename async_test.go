@@ -0,0 +1,109 @@
+package logger
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// slowBuffer is an io.Writer that sleeps briefly before recording each
+// write, standing in for a slow backend.
+type slowBuffer struct {
+	mu    sync.Mutex
+	lines [][]byte
+}
+
+func (b *slowBuffer) Write(p []byte) (int, error) {
+	time.Sleep(time.Millisecond)
+
+	cp := make([]byte, len(p))
+	copy(cp, p)
+
+	b.mu.Lock()
+	b.lines = append(b.lines, cp)
+	b.mu.Unlock()
+	return len(p), nil
+}
+
+func (b *slowBuffer) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.lines)
+}
+
+// withAsyncTestBackend swaps in a slowBuffer as the only backend and
+// restores the previous package state once the test finishes.
+func withAsyncTestBackend(t *testing.T) *slowBuffer {
+	t.Helper()
+
+	origBackends := backends
+	origLevel := requestedLevel
+	t.Cleanup(func() {
+		Close()
+		backends = origBackends
+		requestedLevel = origLevel
+	})
+
+	buf := &slowBuffer{}
+	SetBackends(NewBackend(buf, DebugLevel, TextFormatter{}))
+	SetLevel(DebugLevel)
+	return buf
+}
+
+func TestAsyncFlushWaitsForWrites(t *testing.T) {
+	buf := withAsyncTestBackend(t)
+
+	// The buffer must be sized to exceed the number of records produced,
+	// otherwise enqueue's documented drop-oldest policy can legitimately
+	// discard some of them before Flush ever starts waiting.
+	const n = 20
+	EnableAsync(n * 2)
+
+	for i := 0; i < n; i++ {
+		Info("message %d", i)
+	}
+
+	Flush()
+
+	if got := buf.Len(); got != n {
+		t.Fatalf("after Flush: backend saw %d writes, want %d", got, n)
+	}
+}
+
+func TestAsyncCloseDrainsQueue(t *testing.T) {
+	buf := withAsyncTestBackend(t)
+	EnableAsync(16)
+
+	const n = 10
+	for i := 0; i < n; i++ {
+		Info("message %d", i)
+	}
+
+	Close()
+
+	if got := buf.Len(); got != n {
+		t.Fatalf("after Close: backend saw %d writes, want %d", got, n)
+	}
+}
+
+func TestEnqueueDropsOldestWhenFull(t *testing.T) {
+	queue := make(chan Record, 2)
+	var pending sync.WaitGroup
+
+	enqueue(queue, &pending, Record{Message: "one"})
+	enqueue(queue, &pending, Record{Message: "two"})
+	enqueue(queue, &pending, Record{Message: "three"})
+
+	if got := len(queue); got != 2 {
+		t.Fatalf("queue length = %d, want 2", got)
+	}
+
+	first := <-queue
+	pending.Done()
+	second := <-queue
+	pending.Done()
+
+	if first.Message != "two" || second.Message != "three" {
+		t.Fatalf("expected oldest record dropped, got %q then %q", first.Message, second.Message)
+	}
+}
@@ -0,0 +1,109 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/template"
+	"time"
+)
+
+// Record is a single log event as seen by a Backend's Formatter.
+type Record struct {
+	Level   LogLevel
+	Time    time.Time
+	File    string
+	Line    int
+	Func    string
+	Message string
+	Fields  map[string]interface{}
+}
+
+// Formatter renders a Record into the bytes a Backend writes out.
+type Formatter interface {
+	Format(r Record) []byte
+}
+
+// Backend pairs an io.Writer with a minimum LogLevel and a Formatter. A
+// record is only written to a Backend when its Level admits that record's
+// severity, so different sinks can be configured at different verbosities.
+type Backend struct {
+	Writer    io.Writer
+	Level     LogLevel
+	Formatter Formatter
+}
+
+// NewBackend constructs a Backend from a writer, minimum level, and
+// formatter, mirroring the shape of AddBackend/SetBackends callers.
+func NewBackend(w io.Writer, level LogLevel, f Formatter) Backend {
+	return Backend{Writer: w, Level: level, Formatter: f}
+}
+
+// TextFormatter reproduces the package's original single-line, optionally
+// colorized log format.
+type TextFormatter struct{}
+
+// Format implements Formatter.
+func (TextFormatter) Format(r Record) []byte {
+	line := fmt.Sprintf("%s%s [%s] [%s:%d] %s(): %s",
+		LogColorSet(r.Level),
+		r.Time.Format("2006-01-02 15:04:05.0000"),
+		LogPrefix(r.Level),
+		r.File, r.Line, r.Func,
+		r.Message)
+	if len(r.Fields) > 0 {
+		line += " " + formatFieldsText(r.Fields)
+	}
+	return []byte(line + LogColorReset(r.Level) + "\n")
+}
+
+// JSONFormatter renders a Record as a single line of JSON, suitable for
+// shipping to a log collector.
+type JSONFormatter struct{}
+
+// Format implements Formatter.
+func (JSONFormatter) Format(r Record) []byte {
+	fields := make(map[string]interface{}, len(r.Fields)+6)
+	for k, v := range r.Fields {
+		fields[k] = v
+	}
+	fields["level"] = r.Level.String()
+	fields["time"] = r.Time
+	fields["file"] = r.File
+	fields["line"] = r.Line
+	fields["func"] = r.Func
+	fields["message"] = r.Message
+
+	out, err := json.Marshal(fields)
+	if err != nil {
+		return []byte(err.Error() + "\n")
+	}
+	return append(out, '\n')
+}
+
+// TemplateFormatter renders a Record through a user-supplied text/template,
+// for callers who need a custom line shape that isn't plain text or JSON.
+type TemplateFormatter struct {
+	tmpl *template.Template
+}
+
+// NewTemplateFormatter parses tmpl and returns a TemplateFormatter that
+// executes it against a Record for every log line.
+func NewTemplateFormatter(tmpl string) (*TemplateFormatter, error) {
+	t, err := template.New("logger").Parse(tmpl)
+	if err != nil {
+		return nil, err
+	}
+	return &TemplateFormatter{tmpl: t}, nil
+}
+
+// Format implements Formatter.
+func (f *TemplateFormatter) Format(r Record) []byte {
+	var buf bytes.Buffer
+	if err := f.tmpl.Execute(&buf, r); err != nil {
+		return []byte(err.Error() + "\n")
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes()
+}
@@ -0,0 +1,74 @@
+package logger
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJSONFormatterRoundTrips(t *testing.T) {
+	r := Record{
+		Level:   InfoLevel,
+		Time:    time.Now(),
+		File:    "foo.go",
+		Line:    42,
+		Func:    "pkg.Foo",
+		Message: "hello",
+		Fields:  map[string]interface{}{"req_id": "abc123"},
+	}
+
+	out := JSONFormatter{}.Format(r)
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(out, &decoded); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	if decoded["level"] != "INFO" {
+		t.Errorf("level = %v, want INFO", decoded["level"])
+	}
+	if decoded["message"] != "hello" {
+		t.Errorf("message = %v, want hello", decoded["message"])
+	}
+	if decoded["file"] != "foo.go" {
+		t.Errorf("file = %v, want foo.go", decoded["file"])
+	}
+	if decoded["req_id"] != "abc123" {
+		t.Errorf("req_id = %v, want abc123", decoded["req_id"])
+	}
+}
+
+func TestTextFormatterIncludesFields(t *testing.T) {
+	r := Record{
+		Level:   InfoLevel,
+		Time:    time.Now(),
+		File:    "foo.go",
+		Line:    1,
+		Func:    "pkg.Foo",
+		Message: "hello",
+		Fields:  map[string]interface{}{"a": 1, "b": "two"},
+	}
+
+	out := string(TextFormatter{}.Format(r))
+
+	if !strings.Contains(out, "hello") {
+		t.Errorf("output %q missing message", out)
+	}
+	if !strings.Contains(out, "a=1 b=two") {
+		t.Errorf("output %q missing sorted key=value fields", out)
+	}
+}
+
+func TestTemplateFormatter(t *testing.T) {
+	f, err := NewTemplateFormatter("{{.Level}}: {{.Message}}")
+	if err != nil {
+		t.Fatalf("NewTemplateFormatter: %v", err)
+	}
+
+	out := string(f.Format(Record{Level: ErrorLevel, Message: "boom"}))
+
+	if want := "ERROR: boom\n"; out != want {
+		t.Fatalf("Format = %q, want %q", out, want)
+	}
+}
@@ -0,0 +1,157 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ContextKey is the type callers should use for context.Context values that
+// should be automatically extracted into fields by WithContext. Plain
+// string keys are avoided here for the same collision reasons the standard
+// library recommends against them in context.WithValue.
+type ContextKey string
+
+// contextKeys maps a ContextKey to the field name WithContext should record
+// its value under, when present. Populate it with RegisterContextKey.
+// contextKeysMu guards it since it's typically written once at startup but
+// read from every goroutine that calls WithContext.
+var (
+	contextKeysMu sync.Mutex
+	contextKeys   = map[ContextKey]string{}
+)
+
+// RegisterContextKey causes WithContext to copy ctx.Value(key) into the
+// resulting Entry's fields under name, whenever a context carries that key.
+func RegisterContextKey(key ContextKey, name string) {
+	contextKeysMu.Lock()
+	defer contextKeysMu.Unlock()
+	contextKeys[key] = name
+}
+
+// Entry carries a set of key/value fields to attach to every message logged
+// through it. An Entry is immutable once built: each With* call returns a
+// new Entry, so a single Entry is safe to reuse and to share across
+// goroutines.
+type Entry struct {
+	fields map[string]interface{}
+}
+
+// WithField starts a new Entry carrying a single field.
+func WithField(key string, value interface{}) *Entry {
+	return (&Entry{}).WithField(key, value)
+}
+
+// WithFields starts a new Entry carrying the given fields.
+func WithFields(fields map[string]interface{}) *Entry {
+	return (&Entry{}).WithFields(fields)
+}
+
+// WithContext starts a new Entry with fields extracted from ctx via
+// RegisterContextKey.
+func WithContext(ctx context.Context) *Entry {
+	return (&Entry{}).WithContext(ctx)
+}
+
+// WithField returns a new Entry with key/value merged into e's fields.
+func (e *Entry) WithField(key string, value interface{}) *Entry {
+	return e.WithFields(map[string]interface{}{key: value})
+}
+
+// WithFields returns a new Entry with fields merged into e's fields.
+func (e *Entry) WithFields(fields map[string]interface{}) *Entry {
+	merged := make(map[string]interface{}, len(e.fields)+len(fields))
+	for k, v := range e.fields {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return &Entry{fields: merged}
+}
+
+// WithContext returns a new Entry with any registered context keys found in
+// ctx merged into e's fields.
+func (e *Entry) WithContext(ctx context.Context) *Entry {
+	if ctx == nil {
+		return e
+	}
+
+	contextKeysMu.Lock()
+	keys := make(map[ContextKey]string, len(contextKeys))
+	for k, name := range contextKeys {
+		keys[k] = name
+	}
+	contextKeysMu.Unlock()
+
+	if len(keys) == 0 {
+		return e
+	}
+
+	extracted := make(map[string]interface{})
+	for key, name := range keys {
+		if v := ctx.Value(key); v != nil {
+			extracted[name] = v
+		}
+	}
+	if len(extracted) == 0 {
+		return e
+	}
+	return e.WithFields(extracted)
+}
+
+// Debug sends a debug log message carrying e's fields.
+func (e *Entry) Debug(format string, v ...interface{}) {
+	if requestedLevel >= DebugLevel {
+		formatMessage(DebugLevel, e.fields, format, v...)
+	}
+}
+
+// Info sends an info log message carrying e's fields.
+func (e *Entry) Info(format string, v ...interface{}) {
+	if requestedLevel >= InfoLevel {
+		formatMessage(InfoLevel, e.fields, format, v...)
+	}
+}
+
+// Warn sends a warning log message carrying e's fields.
+func (e *Entry) Warn(format string, v ...interface{}) {
+	if requestedLevel >= WarnLevel {
+		formatMessage(WarnLevel, e.fields, format, v...)
+	}
+}
+
+// Error sends an error log message carrying e's fields.
+func (e *Entry) Error(format string, v ...interface{}) {
+	if requestedLevel >= ErrorLevel {
+		formatMessage(ErrorLevel, e.fields, format, v...)
+	}
+}
+
+// Fatal sends a fatal log message carrying e's fields and stops the
+// execution of the program.
+func (e *Entry) Fatal(format string, v ...interface{}) {
+	if requestedLevel >= FatalLevel {
+		formatMessage(FatalLevel, e.fields, format, v...)
+		os.Exit(1)
+	}
+}
+
+// formatFieldsText renders fields as sorted "key=value" pairs for
+// TextFormatter, so output is deterministic across runs.
+func formatFieldsText(fields map[string]interface{}) string {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = fmt.Sprintf("%s=%v", k, fields[k])
+	}
+	return strings.Join(parts, " ")
+}
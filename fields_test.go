@@ -0,0 +1,45 @@
+package logger
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestEntryWithFieldsIsImmutable(t *testing.T) {
+	base := WithField("a", 1)
+	extended := base.WithField("b", 2)
+
+	if _, ok := base.fields["b"]; ok {
+		t.Fatalf("base.fields mutated by WithField on a derived entry: %v", base.fields)
+	}
+	if want := map[string]interface{}{"a": 1, "b": 2}; !reflect.DeepEqual(extended.fields, want) {
+		t.Fatalf("extended.fields = %v, want %v", extended.fields, want)
+	}
+}
+
+func TestWithContextExtractsRegisteredKey(t *testing.T) {
+	const key ContextKey = "test-request-id"
+	RegisterContextKey(key, "request_id")
+	t.Cleanup(func() {
+		contextKeysMu.Lock()
+		delete(contextKeys, key)
+		contextKeysMu.Unlock()
+	})
+
+	ctx := context.WithValue(context.Background(), key, "req-42")
+	e := WithContext(ctx)
+
+	if got := e.fields["request_id"]; got != "req-42" {
+		t.Fatalf("fields[request_id] = %v, want req-42", got)
+	}
+}
+
+func TestWithContextIgnoresUnregisteredKeys(t *testing.T) {
+	ctx := context.WithValue(context.Background(), ContextKey("unregistered"), "value")
+	e := WithContext(ctx)
+
+	if len(e.fields) != 0 {
+		t.Fatalf("expected no fields extracted, got %v", e.fields)
+	}
+}
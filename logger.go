@@ -7,12 +7,24 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
+	"sync"
 	"time"
 )
 
 var requestedLevel = InfoLevel
 var displayDateTime = false
-var outputDest io.Writer = os.Stderr
+
+// writeMu serializes writes to backends across goroutines.
+var writeMu sync.Mutex
+
+// backends holds every sink that formatted log records are fanned out to.
+// The zero-value configuration preserves the historical behavior of writing
+// plain text to stderr; its Level is DebugLevel so that SetLevel alone
+// decides which records reach it.
+var backends = []Backend{
+	{Writer: os.Stderr, Level: DebugLevel, Formatter: TextFormatter{}},
+}
 
 // LogLevel type represents the different logging levels.
 type LogLevel uint32
@@ -24,12 +36,12 @@ const (
 	// ErrorLevel should be used when someone should really look at the error.
 	ErrorLevel
 
-	// InfoLevel should be used during normal operations.
-	InfoLevel
-
 	// WarnLevel should be used for things that should be addressed at some point.
 	WarnLevel
 
+	// InfoLevel should be used during normal operations.
+	InfoLevel
+
 	// DebugLevel should be used only during development.
 	DebugLevel
 )
@@ -123,12 +135,45 @@ func (level LogLevel) String() string {
 }
 
 // SetOutputToFile sets the log output destination to a file with the given path.
+//
+// This is a convenience wrapper around the default backend; call AddBackend
+// or SetBackends directly for multi-sink setups.
 func SetOutputToFile(logFilePath *string) {
-	var err error
-	outputDest, err = os.OpenFile(*logFilePath, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0666)
+	f, err := os.OpenFile(*logFilePath, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0666)
 	if err != nil {
 		Fatal("could not open log file %s", *logFilePath)
 	}
+	setDefaultWriter(f)
+}
+
+// setDefaultWriter replaces the first configured backend's Writer, adding a
+// default InfoLevel/TextFormatter backend first if none exists (e.g. after
+// SetBackends() was called with no arguments).
+func setDefaultWriter(w io.Writer) {
+	writeMu.Lock()
+	defer writeMu.Unlock()
+
+	if len(backends) == 0 {
+		backends = append(backends, Backend{Level: InfoLevel, Formatter: TextFormatter{}})
+	}
+	backends[0].Writer = w
+}
+
+// AddBackend registers an additional sink. Every backend receives a record
+// whenever the record's level is admitted by that backend's own Level,
+// independent of the other backends configured.
+func AddBackend(b Backend) {
+	writeMu.Lock()
+	defer writeMu.Unlock()
+	backends = append(backends, b)
+}
+
+// SetBackends replaces every currently configured backend with bs. Calling
+// it with no arguments leaves the logger with nowhere to write.
+func SetBackends(bs ...Backend) {
+	writeMu.Lock()
+	defer writeMu.Unlock()
+	backends = bs
 }
 
 // EnableDateTime enables date time in log messages.
@@ -136,56 +181,108 @@ func EnableDateTime() {
 	displayDateTime = true
 }
 
+// SetLevel sets the level of verbosity that will be logged: messages more
+// verbose than level are suppressed. For example, SetLevel(WarnLevel)
+// admits Fatal, Error, and Warn messages but suppresses Info and Debug.
+func SetLevel(level LogLevel) {
+	requestedLevel = level
+}
+
+// ParseLevel parses a level name, case-insensitively, into a LogLevel.
+func ParseLevel(s string) (LogLevel, error) {
+	switch strings.ToUpper(s) {
+	case "FATAL":
+		return FatalLevel, nil
+	case "ERROR":
+		return ErrorLevel, nil
+	case "WARN", "WARNING":
+		return WarnLevel, nil
+	case "INFO":
+		return InfoLevel, nil
+	case "DEBUG":
+		return DebugLevel, nil
+	default:
+		return 0, fmt.Errorf("logger: unknown level %q", s)
+	}
+}
+
 // EnableDebug increases logging, more verbose (debug)
 func EnableDebug() {
 	requestedLevel = DebugLevel
-	formatMessage(InfoLevel, "Debug mode enabled")
+	formatMessage(InfoLevel, nil, "Debug mode enabled")
 }
 
 // Debug sends a debug log message.
 func Debug(format string, v ...interface{}) {
 	if requestedLevel >= DebugLevel {
-		formatMessage(DebugLevel, format, v...)
+		formatMessage(DebugLevel, nil, format, v...)
 	}
 }
 
 // Info sends an info log message.
 func Info(format string, v ...interface{}) {
 	if requestedLevel >= InfoLevel {
-		formatMessage(InfoLevel, format, v...)
+		formatMessage(InfoLevel, nil, format, v...)
 	}
 }
 
-// Warn sends an info log message.
+// Warn sends a warning log message.
 func Warn(format string, v ...interface{}) {
-	if requestedLevel >= InfoLevel {
-		formatMessage(InfoLevel, format, v...)
+	if requestedLevel >= WarnLevel {
+		formatMessage(WarnLevel, nil, format, v...)
 	}
 }
 
 // Error sends an error log message.
 func Error(format string, v ...interface{}) {
 	if requestedLevel >= ErrorLevel {
-		formatMessage(ErrorLevel, format, v...)
+		formatMessage(ErrorLevel, nil, format, v...)
 	}
 }
 
 // Fatal sends a fatal log message and stop the execution of the program.
 func Fatal(format string, v ...interface{}) {
 	if requestedLevel >= FatalLevel {
-		formatMessage(FatalLevel, format, v...)
+		formatMessage(FatalLevel, nil, format, v...)
 		os.Exit(1)
 	}
 }
 
-// formatMessage formats and writes the log message to the output destination.
-func formatMessage(level LogLevel, format string, v ...interface{}) {
+// formatMessage builds a Record for the call site, attaching fields if any
+// were supplied, and either writes it out immediately or, once EnableAsync
+// has been called, hands it to the async pipeline.
+func formatMessage(level LogLevel, fields map[string]interface{}, format string, v ...interface{}) {
 	pc, filename, line, _ := runtime.Caller(2)
-	logmsg := fmt.Sprintf(format, v...)
-	fmt.Fprintf(outputDest, "%s%s [%s] [%s:%d] %s(): %s%s\n", LogColorSet(level),
-		LogTimestamp(),
-		LogPrefix(level),
-		filepath.Base(filename), line, runtime.FuncForPC(pc).Name(),
-		logmsg,
-		LogColorReset(level))
+	r := Record{
+		Level:   level,
+		Time:    time.Now(),
+		File:    filepath.Base(filename),
+		Line:    line,
+		Func:    runtime.FuncForPC(pc).Name(),
+		Message: fmt.Sprintf(format, v...),
+		Fields:  fields,
+	}
+
+	publish(r)
+
+	if p, ok := asyncEnabled(); ok {
+		enqueue(p.queue, &p.pending, r)
+		return
+	}
+	writeRecord(r)
+}
+
+// writeRecord writes r to every backend whose Level admits it. All writes
+// are serialized by writeMu so concurrent goroutines logging at the same
+// time can't interleave partial writes to a shared io.Writer.
+func writeRecord(r Record) {
+	writeMu.Lock()
+	defer writeMu.Unlock()
+
+	for _, b := range backends {
+		if r.Level > b.Level {
+			continue
+		}
+		b.Writer.Write(b.Formatter.Format(r))
+	}
 }
@@ -0,0 +1,64 @@
+package logger
+
+import "testing"
+
+func TestLevelOrdering(t *testing.T) {
+	if !(FatalLevel < ErrorLevel && ErrorLevel < WarnLevel && WarnLevel < InfoLevel && InfoLevel < DebugLevel) {
+		t.Fatalf("expected Fatal < Error < Warn < Info < Debug, got %d %d %d %d %d",
+			FatalLevel, ErrorLevel, WarnLevel, InfoLevel, DebugLevel)
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	cases := map[string]LogLevel{
+		"fatal":   FatalLevel,
+		"ERROR":   ErrorLevel,
+		"Warn":    WarnLevel,
+		"warning": WarnLevel,
+		"info":    InfoLevel,
+		"debug":   DebugLevel,
+	}
+	for in, want := range cases {
+		got, err := ParseLevel(in)
+		if err != nil {
+			t.Fatalf("ParseLevel(%q) returned error: %v", in, err)
+		}
+		if got != want {
+			t.Fatalf("ParseLevel(%q) = %v, want %v", in, got, want)
+		}
+	}
+
+	if _, err := ParseLevel("bogus"); err == nil {
+		t.Fatal(`ParseLevel("bogus") expected an error, got nil`)
+	}
+}
+
+// TestSetLevelGating checks, for every threshold, that SetLevel admits
+// exactly the levels at or below it and suppresses the rest.
+func TestSetLevelGating(t *testing.T) {
+	orig := requestedLevel
+	defer SetLevel(orig)
+
+	emit := map[LogLevel]func(){
+		ErrorLevel: func() { Error("x") },
+		WarnLevel:  func() { Warn("x") },
+		InfoLevel:  func() { Info("x") },
+		DebugLevel: func() { Debug("x") },
+	}
+
+	for _, threshold := range []LogLevel{FatalLevel, ErrorLevel, WarnLevel, InfoLevel, DebugLevel} {
+		SetLevel(threshold)
+
+		for level, fn := range emit {
+			ch := Subscribe()
+			fn()
+			Unsubscribe(ch)
+
+			_, admitted := <-ch
+			wantAdmitted := level <= threshold
+			if admitted != wantAdmitted {
+				t.Errorf("threshold=%v level=%v: got admitted=%v, want %v", threshold, level, admitted, wantAdmitted)
+			}
+		}
+	}
+}
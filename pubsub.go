@@ -0,0 +1,64 @@
+package logger
+
+import "sync"
+
+// subscriberBufferSize is the channel capacity given to each Subscribe
+// call; a subscriber that falls further behind than this is detached.
+const subscriberBufferSize = 64
+
+var (
+	subsMu      sync.Mutex
+	subscribers = map[chan Record]struct{}{}
+)
+
+// Subscribe registers a new subscriber that receives every Record logged
+// from this point on, following the observable pattern: publish is a no-op
+// until at least one subscriber exists, so the feature costs nothing when
+// unused. A subscriber that can't keep up is detached (and its channel
+// closed) rather than blocking the logging path. Call Unsubscribe once the
+// caller no longer needs the channel.
+func Subscribe() <-chan Record {
+	ch := make(chan Record, subscriberBufferSize)
+
+	subsMu.Lock()
+	subscribers[ch] = struct{}{}
+	subsMu.Unlock()
+
+	return ch
+}
+
+// Unsubscribe detaches ch and closes it. Safe to call more than once, and
+// safe to call after the subscriber was already detached for falling
+// behind.
+func Unsubscribe(ch <-chan Record) {
+	subsMu.Lock()
+	defer subsMu.Unlock()
+
+	for c := range subscribers {
+		if c == ch {
+			delete(subscribers, c)
+			close(c)
+			return
+		}
+	}
+}
+
+// publish fans r out to every current subscriber, detaching (and closing)
+// any that aren't keeping up instead of blocking the caller.
+func publish(r Record) {
+	subsMu.Lock()
+	defer subsMu.Unlock()
+
+	if len(subscribers) == 0 {
+		return
+	}
+
+	for ch := range subscribers {
+		select {
+		case ch <- r:
+		default:
+			delete(subscribers, ch)
+			close(ch)
+		}
+	}
+}
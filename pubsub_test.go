@@ -0,0 +1,64 @@
+package logger
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSubscribeReceivesPublishedRecord(t *testing.T) {
+	ch := Subscribe()
+	defer Unsubscribe(ch)
+
+	want := Record{Message: "hello"}
+	publish(want)
+
+	select {
+	case got := <-ch:
+		if got.Message != want.Message {
+			t.Fatalf("got message %q, want %q", got.Message, want.Message)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for published record")
+	}
+}
+
+func TestUnsubscribeClosesChannel(t *testing.T) {
+	ch := Subscribe()
+	Unsubscribe(ch)
+
+	if _, ok := <-ch; ok {
+		t.Fatal("expected channel to be closed after Unsubscribe")
+	}
+}
+
+func TestSlowSubscriberIsDetachedNotBlocked(t *testing.T) {
+	ch := Subscribe()
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < subscriberBufferSize+5; i++ {
+			publish(Record{Message: "x"})
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("publish blocked on a slow subscriber instead of detaching it")
+	}
+
+	// Drain whatever was buffered before the detach; the channel must end
+	// up closed, proving the subscriber was detached rather than left
+	// registered to block future publishes.
+	closed := false
+	for i := 0; i < subscriberBufferSize+5; i++ {
+		if _, ok := <-ch; !ok {
+			closed = true
+			break
+		}
+	}
+	if !closed {
+		t.Fatal("expected channel to be closed after subscriber was detached")
+	}
+}
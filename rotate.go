@@ -0,0 +1,201 @@
+package logger
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// RotateOptions configures the size/age/backup-count rotation policy used
+// by SetRotatingFileOutput.
+type RotateOptions struct {
+	// MaxSizeMB is the size, in megabytes, a log file is allowed to reach
+	// before it is rotated out. Zero disables size-based rotation.
+	MaxSizeMB int
+
+	// MaxAgeDays is the maximum age, in days, a rotated backup is kept
+	// before being pruned. Zero means backups are never pruned by age.
+	MaxAgeDays int
+
+	// MaxBackups is the maximum number of rotated backups kept. Zero means
+	// backups are never pruned by count.
+	MaxBackups int
+
+	// Compress gzips rotated backups instead of leaving them as plain text.
+	Compress bool
+
+	// LocalTime uses the local timezone for a backup's timestamp suffix
+	// instead of UTC.
+	LocalTime bool
+}
+
+// rotatingFile is an io.Writer over a single log file that rotates itself
+// once a write would push the file past opts.MaxSizeMB. Write is only ever
+// called from within writeRecord, which already holds writeMu, so
+// rotatingFile does no locking of its own.
+type rotatingFile struct {
+	path string
+	opts RotateOptions
+
+	file *os.File
+	size int64
+}
+
+// newRotatingFile opens path in append mode and prepares it for rotation.
+func newRotatingFile(path string, opts RotateOptions) (*rotatingFile, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0666)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return &rotatingFile{path: path, opts: opts, file: f, size: info.Size()}, nil
+}
+
+// Write implements io.Writer, rotating the file first if p would push it
+// past opts.MaxSizeMB.
+func (rf *rotatingFile) Write(p []byte) (int, error) {
+	maxSize := int64(rf.opts.MaxSizeMB) * 1024 * 1024
+	if maxSize > 0 && rf.size+int64(len(p)) > maxSize {
+		if err := rf.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := rf.file.Write(p)
+	rf.size += int64(n)
+	return n, err
+}
+
+// rotate renames the current file to a timestamped backup, optionally
+// compresses it, reopens path, and kicks off background pruning.
+func (rf *rotatingFile) rotate() error {
+	if err := rf.file.Close(); err != nil {
+		return err
+	}
+
+	ts := time.Now()
+	if !rf.opts.LocalTime {
+		ts = ts.UTC()
+	}
+	backupPath := fmt.Sprintf("%s.%s", rf.path, ts.Format("20060102T150405.000"))
+
+	if err := os.Rename(rf.path, backupPath); err != nil {
+		return err
+	}
+
+	if rf.opts.Compress {
+		go compressBackup(backupPath)
+	}
+
+	f, err := os.OpenFile(rf.path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0666)
+	if err != nil {
+		return err
+	}
+	rf.file = f
+	rf.size = 0
+
+	go pruneBackups(rf.path, rf.opts)
+	return nil
+}
+
+// compressBackup gzips path in place, removing the uncompressed copy once
+// the compressed one is written successfully.
+func compressBackup(path string) {
+	src, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer src.Close()
+
+	dst, err := os.Create(path + ".gz")
+	if err != nil {
+		return
+	}
+	defer dst.Close()
+
+	gz := gzip.NewWriter(dst)
+	if _, err := io.Copy(gz, src); err != nil {
+		gz.Close()
+		return
+	}
+	if err := gz.Close(); err != nil {
+		return
+	}
+
+	os.Remove(path)
+}
+
+// pruneBackups removes backups of path beyond opts.MaxBackups and older
+// than opts.MaxAgeDays.
+func pruneBackups(path string, opts RotateOptions) {
+	if opts.MaxBackups <= 0 && opts.MaxAgeDays <= 0 {
+		return
+	}
+
+	matches, err := filepath.Glob(path + ".*")
+	if err != nil {
+		return
+	}
+
+	type backup struct {
+		path    string
+		modTime time.Time
+	}
+	backups := make([]backup, 0, len(matches))
+	for _, m := range matches {
+		info, err := os.Stat(m)
+		if err != nil {
+			continue
+		}
+		backups = append(backups, backup{path: m, modTime: info.ModTime()})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].modTime.After(backups[j].modTime)
+	})
+
+	if opts.MaxAgeDays > 0 {
+		cutoff := time.Now().AddDate(0, 0, -opts.MaxAgeDays)
+		kept := backups[:0]
+		for _, b := range backups {
+			if b.modTime.Before(cutoff) {
+				os.Remove(b.path)
+				continue
+			}
+			kept = append(kept, b)
+		}
+		backups = kept
+	}
+
+	if opts.MaxBackups > 0 && len(backups) > opts.MaxBackups {
+		for _, b := range backups[opts.MaxBackups:] {
+			os.Remove(b.path)
+		}
+	}
+}
+
+// SetRotatingFileOutput sets the log output destination to a file at path
+// that rotates according to opts: once a write would push the file past
+// opts.MaxSizeMB, the current file is renamed to path.<timestamp>
+// (optionally gzipped) and a fresh file is opened at path, with a
+// background goroutine pruning backups beyond opts.MaxBackups and older
+// than opts.MaxAgeDays. Like SetOutputToFile, it replaces the default
+// backend's writer, and its writes go through the same writeMu as every
+// other backend.
+func SetRotatingFileOutput(path string, opts RotateOptions) {
+	rf, err := newRotatingFile(path, opts)
+	if err != nil {
+		Fatal("could not open log file %s", path)
+	}
+	setDefaultWriter(rf)
+}
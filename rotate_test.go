@@ -0,0 +1,90 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRotatingFileRotatesOnSize(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test.log")
+
+	rf, err := newRotatingFile(path, RotateOptions{MaxSizeMB: 1})
+	if err != nil {
+		t.Fatalf("newRotatingFile: %v", err)
+	}
+
+	if _, err := rf.Write([]byte("first line\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	// Force the tracked size past the MaxSizeMB threshold without actually
+	// writing a megabyte of data, then write again to trigger rotation.
+	rf.size = int64(rf.opts.MaxSizeMB)*1024*1024 + 1
+
+	if _, err := rf.Write([]byte("second line\n")); err != nil {
+		t.Fatalf("Write after forced size: %v", err)
+	}
+
+	matches, err := filepath.Glob(path + ".*")
+	if err != nil {
+		t.Fatalf("Glob: %v", err)
+	}
+	if len(matches) != 1 {
+		t.Fatalf("expected exactly one rotated backup, got %v", matches)
+	}
+
+	backup, err := os.ReadFile(matches[0])
+	if err != nil {
+		t.Fatalf("reading backup: %v", err)
+	}
+	if string(backup) != "first line\n" {
+		t.Fatalf("backup contents = %q, want %q", backup, "first line\n")
+	}
+
+	active, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading active file: %v", err)
+	}
+	if string(active) != "second line\n" {
+		t.Fatalf("active file contents = %q, want %q", active, "second line\n")
+	}
+
+	if want := int64(len("second line\n")); rf.size != want {
+		t.Fatalf("rf.size = %d, want %d", rf.size, want)
+	}
+}
+
+func TestPruneBackupsByMaxBackups(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test.log")
+
+	for i := 0; i < 3; i++ {
+		backup := fmt.Sprintf("%s.%d", path, i)
+		if err := os.WriteFile(backup, []byte("x"), 0666); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+		// Give each backup a distinct, increasing mod time so pruning by
+		// count has a deterministic oldest/newest to choose between.
+		modTime := time.Now().Add(time.Duration(i) * time.Second)
+		if err := os.Chtimes(backup, modTime, modTime); err != nil {
+			t.Fatalf("Chtimes: %v", err)
+		}
+	}
+
+	pruneBackups(path, RotateOptions{MaxBackups: 1})
+
+	matches, err := filepath.Glob(path + ".*")
+	if err != nil {
+		t.Fatalf("Glob: %v", err)
+	}
+	if len(matches) != 1 {
+		t.Fatalf("expected 1 backup to remain, got %v", matches)
+	}
+	if want := path + ".2"; matches[0] != want {
+		t.Fatalf("expected newest backup %s to remain, got %v", want, matches)
+	}
+}